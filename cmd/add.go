@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// addCmd validates, builds, and optionally installs the service for a Go
+// project. It is the original one-shot behavior of vbuilder, now a
+// subcommand alongside the lifecycle commands.
+var addCmd = &cobra.Command{
+	Use:   "add [project-path]",
+	Short: "Validate, build, and create a service for a Go project",
+	Args:  cobra.ExactArgs(1),
+	Run:   runProjectSetup,
+}
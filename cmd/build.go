@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/vorticist/builder/service"
+	"github.com/vorticist/logger"
+)
+
+// BuildOptions controls how buildGoProject invokes the Go toolchain.
+type BuildOptions struct {
+	GOOS       string
+	GOARCH     string
+	CGOEnabled bool
+	Tags       []string
+	LDFlags    []string
+	Trimpath   bool
+	VersionVar string
+}
+
+// targetOS/targetArch fall back to the host's own GOOS/GOARCH when the
+// option is left unset, i.e. for an ordinary native build.
+func (o BuildOptions) targetOS() string {
+	if o.GOOS != "" {
+		return o.GOOS
+	}
+	return runtime.GOOS
+}
+
+func (o BuildOptions) targetArch() string {
+	if o.GOARCH != "" {
+		return o.GOARCH
+	}
+	return runtime.GOARCH
+}
+
+// matchesHost reports whether this target is the machine vbuilder is
+// running on, i.e. whether the resulting binary can be installed as a
+// service here.
+func (o BuildOptions) matchesHost() bool {
+	return o.targetOS() == runtime.GOOS && o.targetArch() == runtime.GOARCH
+}
+
+// outputPath mirrors the original behavior for native builds (binary in the
+// project root) and places cross-compiled binaries under dist/<os>_<arch>/.
+func (o BuildOptions) outputPath(projectPath, binaryName string) string {
+	if o.GOOS == "" && o.GOARCH == "" {
+		return filepath.Join(projectPath, binaryName)
+	}
+	return filepath.Join(projectPath, "dist", fmt.Sprintf("%s_%s", o.targetOS(), o.targetArch()), binaryName)
+}
+
+func (o BuildOptions) ldflags() string {
+	flags := append([]string{}, o.LDFlags...)
+	if o.VersionVar != "" {
+		flags = append(flags, "-X", o.VersionVar)
+	}
+	return strings.Join(flags, " ")
+}
+
+func (o BuildOptions) env() []string {
+	env := os.Environ()
+	if o.GOOS != "" {
+		env = append(env, "GOOS="+o.GOOS)
+	}
+	if o.GOARCH != "" {
+		env = append(env, "GOARCH="+o.GOARCH)
+	}
+	if o.CGOEnabled {
+		env = append(env, "CGO_ENABLED=1")
+	} else {
+		env = append(env, "CGO_ENABLED=0")
+	}
+	return env
+}
+
+func buildGoProject(projectPath, binaryName string, opts BuildOptions) (string, error) {
+	binaryPath := opts.outputPath(projectPath, binaryName)
+	if err := os.MkdirAll(filepath.Dir(binaryPath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %v", err)
+	}
+
+	args := []string{"build", "-o", binaryPath}
+	if opts.Trimpath {
+		args = append(args, "-trimpath")
+	}
+	if len(opts.Tags) > 0 {
+		args = append(args, "-tags", strings.Join(opts.Tags, ","))
+	}
+	if ldflags := opts.ldflags(); ldflags != "" {
+		args = append(args, "-ldflags", ldflags)
+	}
+	args = append(args, projectPath)
+
+	cmd := exec.Command("go", args...)
+	cmd.Dir = projectPath
+	cmd.Env = opts.env()
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", err
+	}
+
+	absBinaryPath, err := filepath.Abs(binaryPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path of binary: %v", err)
+	}
+	return absBinaryPath, nil
+}
+
+// MatrixTarget is one GOOS/GOARCH pair built from --matrix.
+type MatrixTarget struct {
+	GOOS       string
+	GOARCH     string
+	BinaryPath string
+}
+
+// parseMatrix splits a --matrix value like "linux/amd64,darwin/arm64" into
+// individual GOOS/GOARCH pairs.
+func parseMatrix(matrix string) ([][2]string, error) {
+	var pairs [][2]string
+	for _, entry := range strings.Split(matrix, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.Split(entry, "/")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid --matrix target %q, expected os/arch", entry)
+		}
+		pairs = append(pairs, [2]string{parts[0], parts[1]})
+	}
+	if len(pairs) == 0 {
+		return nil, fmt.Errorf("--matrix requires at least one os/arch target")
+	}
+	return pairs, nil
+}
+
+// buildMatrix builds one binary per target in matrix. For targets that
+// don't match the host, it also writes a service manifest alongside the
+// binary, tailored to that target's deploy path, since vbuilder can't
+// install a service on a machine it isn't running on.
+func buildMatrix(projectPath, binaryName, matrix string, base BuildOptions, cfg service.ServiceConfig) ([]MatrixTarget, error) {
+	pairs, err := parseMatrix(matrix)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []MatrixTarget
+	for _, pair := range pairs {
+		opts := base
+		opts.GOOS, opts.GOARCH = pair[0], pair[1]
+
+		binaryPath, err := buildGoProject(projectPath, binaryName, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build %s/%s: %v", pair[0], pair[1], err)
+		}
+		logger.Infof("Built %s for %s/%s", binaryPath, pair[0], pair[1])
+
+		if !opts.matchesHost() {
+			writeTargetManifest(binaryName, binaryPath, opts.GOOS, cfg)
+		}
+
+		results = append(results, MatrixTarget{GOOS: pair[0], GOARCH: pair[1], BinaryPath: binaryPath})
+	}
+	return results, nil
+}
+
+// writeTargetManifest renders a service manifest for goos next to
+// binaryPath, so a matrix build can be deployed to its target without a
+// live vbuilder install on that machine.
+func writeTargetManifest(binaryName, binaryPath, goos string, cfg service.ServiceConfig) {
+	cfg.Name = binaryName
+	cfg.ExecStart = remoteBinaryPath(binaryName)
+	cfg.WorkingDir = filepath.Dir(cfg.ExecStart)
+
+	manifest, err := service.RenderManifest(goos, cfg)
+	if err != nil {
+		logger.Errorf("No service manifest available for %s: %v", goos, err)
+		return
+	}
+
+	manifestPath := filepath.Join(filepath.Dir(binaryPath), binaryName+manifestExt(goos))
+	if err := os.WriteFile(manifestPath, []byte(manifest), 0644); err != nil {
+		logger.Errorf("Failed to write manifest for %s: %v", goos, err)
+		return
+	}
+	logger.Infof("Wrote service manifest for %s at: %v", goos, manifestPath)
+}
+
+// selectInstallBinary picks which matrix result to install: for a --remote
+// deploy it's the linux target (the only one vbuilder can SSH-deploy a
+// systemd unit for), otherwise it's whichever target matches this host.
+func selectInstallBinary(results []MatrixTarget, remote bool) string {
+	if remote {
+		for _, result := range results {
+			if result.GOOS == "linux" {
+				return result.BinaryPath
+			}
+		}
+		return ""
+	}
+
+	for _, result := range results {
+		if result.GOOS == runtime.GOOS && result.GOARCH == runtime.GOARCH {
+			return result.BinaryPath
+		}
+	}
+	return ""
+}
+
+func manifestExt(goos string) string {
+	if goos == "darwin" {
+		return ".plist"
+	}
+	return ".service"
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"debug/buildinfo"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vorticist/builder/service"
+	"github.com/vorticist/logger"
+)
+
+var watchInterval time.Duration
+
+func init() {
+	rootCmd.AddCommand(checkCmd)
+	rootCmd.AddCommand(watchCmd)
+
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", 5*time.Minute, "How often to check for a Go toolchain upgrade")
+}
+
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Rebuild and redeploy registered projects built with a stale Go toolchain",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := checkRegisteredProjects(); err != nil {
+			logger.Errorf("%v", err)
+		}
+	},
+}
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Run check on a repeating interval, keeping registered services patched after a Go upgrade",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		for {
+			if err := checkRegisteredProjects(); err != nil {
+				logger.Errorf("%v", err)
+			}
+			time.Sleep(watchInterval)
+		}
+	},
+}
+
+// checkRegisteredProjects compares the Go toolchain each registered project
+// was built with against the currently-installed `go version`, rebuilding
+// and redeploying any that have drifted.
+func checkRegisteredProjects() error {
+	records, err := loadRegistry()
+	if err != nil {
+		return err
+	}
+
+	goVersion, err := currentGoVersion()
+	if err != nil {
+		return fmt.Errorf("failed to determine installed Go version: %v", err)
+	}
+
+	for _, record := range records {
+		if err := checkProject(record, goVersion); err != nil {
+			logger.Errorf("%s: %v", record.ModuleName, err)
+		}
+	}
+	return nil
+}
+
+func checkProject(record ProjectRecord, goVersion string) error {
+	info, err := buildinfo.ReadFile(record.BinaryPath)
+	if err != nil {
+		return fmt.Errorf("failed to read build info for %s: %v", record.BinaryPath, err)
+	}
+
+	if info.GoVersion == goVersion {
+		return nil
+	}
+
+	logger.Infof("%s: built with %s, host has %s, rebuilding", record.ModuleName, info.GoVersion, goVersion)
+
+	output, buildErr := rebuildProject(record)
+	if err := appendRebuildLog(record.ModuleName, output, buildErr); err != nil {
+		logger.Errorf("Failed to write rebuild log: %v", err)
+	}
+	if buildErr != nil {
+		return fmt.Errorf("rebuild failed: %v", buildErr)
+	}
+
+	if record.Owner != "" {
+		if err := chownBinary(record.BinaryPath, record.Owner, record.Group); err != nil {
+			return fmt.Errorf("chown failed: %v", err)
+		}
+	}
+
+	if record.DeployPath != "" {
+		if err := copyBinary(record.BinaryPath, record.DeployPath); err != nil {
+			return fmt.Errorf("failed to copy binary to deploy path: %v", err)
+		}
+	}
+
+	if record.InstalledService != "" {
+		manager, err := service.New(service.ServiceConfig{Name: record.InstalledService, UserScope: record.UserScope})
+		if err != nil {
+			return err
+		}
+		if err := manager.Stop(); err != nil {
+			logger.Errorf("%s: failed to stop before restart: %v", record.InstalledService, err)
+		}
+		if err := manager.Start(); err != nil {
+			return fmt.Errorf("failed to restart service: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func currentGoVersion() (string, error) {
+	out, err := exec.Command("go", "version").Output()
+	if err != nil {
+		return "", err
+	}
+
+	fields := strings.Fields(string(out))
+	if len(fields) < 3 {
+		return "", fmt.Errorf("unexpected `go version` output: %s", out)
+	}
+	return fields[2], nil
+}
+
+func rebuildProject(record ProjectRecord) (string, error) {
+	buildCmd := exec.Command("go", "build", "-o", record.BinaryPath, record.RootPath)
+	buildCmd.Dir = record.RootPath
+	output, err := buildCmd.CombinedOutput()
+	return string(output), err
+}
+
+func chownBinary(path, owner, group string) error {
+	spec := owner
+	if group != "" {
+		spec = owner + ":" + group
+	}
+	return exec.Command("sudo", "chown", spec, path).Run()
+}
+
+func copyBinary(src, dst string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, 0755)
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/vorticist/builder/service"
+	"gopkg.in/yaml.v3"
+)
+
+// loadProjectServiceConfig reads an optional vbuilder.yaml or vbuilder.json
+// from the project root and returns the ServiceConfig it describes. If
+// neither file is present it returns a zero-value config and no error.
+func loadProjectServiceConfig(projectPath string) (service.ServiceConfig, error) {
+	var cfg service.ServiceConfig
+
+	for _, name := range []string{"vbuilder.yaml", "vbuilder.yml", "vbuilder.json"} {
+		path := filepath.Join(projectPath, name)
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return cfg, fmt.Errorf("failed to read %s: %v", name, err)
+		}
+
+		if filepath.Ext(name) == ".json" {
+			if err := json.Unmarshal(data, &cfg); err != nil {
+				return cfg, fmt.Errorf("failed to parse %s: %v", name, err)
+			}
+		} else {
+			if err := yaml.Unmarshal(data, &cfg); err != nil {
+				return cfg, fmt.Errorf("failed to parse %s: %v", name, err)
+			}
+		}
+		return cfg, nil
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vorticist/builder/service"
+	"github.com/vorticist/logger"
+)
+
+var followLogs bool
+
+func init() {
+	rootCmd.AddCommand(startCmd)
+	rootCmd.AddCommand(stopCmd)
+	rootCmd.AddCommand(restartCmd)
+	rootCmd.AddCommand(statusCmd)
+	rootCmd.AddCommand(uninstallCmd)
+	rootCmd.AddCommand(logsCmd)
+
+	logsCmd.Flags().BoolVarP(&followLogs, "follow", "f", false, "Follow log output as it's written")
+}
+
+var startCmd = &cobra.Command{
+	Use:   "start <name>",
+	Short: "Start an installed service",
+	Args:  cobra.ExactArgs(1),
+	Run:   lifecycleCmd(func(m service.Manager) error { return m.Start() }),
+}
+
+var stopCmd = &cobra.Command{
+	Use:   "stop <name>",
+	Short: "Stop a running service",
+	Args:  cobra.ExactArgs(1),
+	Run:   lifecycleCmd(func(m service.Manager) error { return m.Stop() }),
+}
+
+var restartCmd = &cobra.Command{
+	Use:   "restart <name>",
+	Short: "Stop and start a service",
+	Args:  cobra.ExactArgs(1),
+	Run: lifecycleCmd(func(m service.Manager) error {
+		if err := m.Stop(); err != nil {
+			return err
+		}
+		return m.Start()
+	}),
+}
+
+var uninstallCmd = &cobra.Command{
+	Use:   "uninstall <name>",
+	Short: "Stop and remove an installed service",
+	Args:  cobra.ExactArgs(1),
+	Run:   lifecycleCmd(func(m service.Manager) error { return m.Uninstall() }),
+}
+
+var statusCmd = &cobra.Command{
+	Use:   "status <name>",
+	Short: "Show a service's status, confirming its reported PID is actually running",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		scope, err := resolveServiceScope(cmd, args[0])
+		if err != nil {
+			logger.Errorf("%v", err)
+			return
+		}
+
+		manager, err := service.New(service.ServiceConfig{Name: args[0], UserScope: scope})
+		if err != nil {
+			logger.Errorf("%v", err)
+			return
+		}
+
+		status, err := manager.Status()
+		if err != nil {
+			logger.Errorf("Failed to query status: %v", err)
+		}
+
+		fmt.Print(status.Raw)
+		if status.PID > 0 {
+			logger.Infof("Reported PID %d, process running: %v", status.PID, status.Running)
+		}
+	},
+}
+
+var logsCmd = &cobra.Command{
+	Use:   "logs <name>",
+	Short: "Show logs for a service",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		scope, err := resolveServiceScope(cmd, args[0])
+		if err != nil {
+			logger.Errorf("%v", err)
+			return
+		}
+
+		if err := streamLogs(args[0], scope); err != nil {
+			logger.Errorf("Failed to read logs: %v", err)
+		}
+	},
+}
+
+// lifecycleCmd adapts a Manager action into a cobra Run func. Lifecycle
+// commands only need a service's name and scope, not its full config.
+func lifecycleCmd(action func(service.Manager) error) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		scope, err := resolveServiceScope(cmd, args[0])
+		if err != nil {
+			logger.Errorf("%v", err)
+			return
+		}
+
+		manager, err := service.New(service.ServiceConfig{Name: args[0], UserScope: scope})
+		if err != nil {
+			logger.Errorf("%v", err)
+			return
+		}
+
+		if err := action(manager); err != nil {
+			logger.Errorf("%v", err)
+			return
+		}
+
+		logger.Infof("%s: done", args[0])
+	}
+}
+
+// streamLogs shells out to the platform's native log viewer for name.
+func streamLogs(name string, userScope bool) error {
+	var logCmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "linux":
+		args := []string{"-u", name + ".service"}
+		if userScope {
+			args = append([]string{"--user"}, args...)
+		}
+		if followLogs {
+			args = append(args, "-f")
+		}
+		logCmd = exec.Command("journalctl", args...)
+	case "darwin":
+		predicate := fmt.Sprintf("process == %q", name)
+		if followLogs {
+			logCmd = exec.Command("log", "stream", "--predicate", predicate, "--info")
+		} else {
+			logCmd = exec.Command("log", "show", "--predicate", predicate, "--info")
+		}
+	case "windows":
+		logCmd = exec.Command("powershell", "-Command",
+			fmt.Sprintf("Get-EventLog -LogName Application -Source %q -Newest 50", name))
+	default:
+		return fmt.Errorf("service: logs not supported on %s", runtime.GOOS)
+	}
+
+	logCmd.Stdout = os.Stdout
+	logCmd.Stderr = os.Stderr
+	return logCmd.Run()
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const maxRebuildLogSize = 10 * 1024 * 1024 // 10MB
+
+func rebuildLogPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %v", err)
+	}
+	return filepath.Join(home, ".local/state/vbuilder/rebuild.log"), nil
+}
+
+// appendRebuildLog records the outcome of a rebuild attempt, capturing
+// combined stdout+stderr, rotating the log file once it grows past
+// maxRebuildLogSize.
+func appendRebuildLog(moduleName, output string, buildErr error) error {
+	path, err := rebuildLogPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %v", err)
+	}
+
+	if info, err := os.Stat(path); err == nil && info.Size() > maxRebuildLogSize {
+		if err := os.Rename(path, path+".1"); err != nil {
+			return fmt.Errorf("failed to rotate rebuild log: %v", err)
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open rebuild log: %v", err)
+	}
+	defer file.Close()
+
+	status := "ok"
+	if buildErr != nil {
+		status = fmt.Sprintf("error: %v", buildErr)
+	}
+
+	_, err = fmt.Fprintf(file, "[%s] %s: %s\n%s\n", time.Now().Format(time.RFC3339), moduleName, status, output)
+	return err
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ProjectRecord is a previously-built project vbuilder knows how to rebuild
+// and redeploy when the Go toolchain it was built with goes stale.
+type ProjectRecord struct {
+	RootPath         string `json:"root_path"`
+	BinaryPath       string `json:"binary_path"`
+	ModuleName       string `json:"module_name"`
+	InstalledService string `json:"installed_service,omitempty"`
+	// UserScope records whether InstalledService was installed with --user
+	// or --system, so later commands (check, lifecycleCmd) can target the
+	// right scope without re-deriving it from the invoking user.
+	UserScope  bool   `json:"user_scope,omitempty"`
+	DeployPath string `json:"deploy_path,omitempty"`
+	Owner      string `json:"owner,omitempty"`
+	Group      string `json:"group,omitempty"`
+}
+
+// findByService returns the record whose InstalledService matches name.
+func findByService(name string) (ProjectRecord, bool, error) {
+	records, err := loadRegistry()
+	if err != nil {
+		return ProjectRecord{}, false, err
+	}
+	for _, record := range records {
+		if record.InstalledService == name {
+			return record, true, nil
+		}
+	}
+	return ProjectRecord{}, false, nil
+}
+
+func registryPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %v", err)
+	}
+	return filepath.Join(home, ".config/vbuilder/projects.json"), nil
+}
+
+// loadRegistry returns the previously-built projects, or nil if none have
+// been recorded yet.
+func loadRegistry() ([]ProjectRecord, error) {
+	path, err := registryPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project registry: %v", err)
+	}
+
+	var records []ProjectRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse project registry: %v", err)
+	}
+	return records, nil
+}
+
+func saveRegistry(records []ProjectRecord) error {
+	path, err := registryPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create registry directory: %v", err)
+	}
+
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode project registry: %v", err)
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+// upsertRegistry adds or replaces the record for record.ModuleName.
+func upsertRegistry(record ProjectRecord) error {
+	records, err := loadRegistry()
+	if err != nil {
+		return err
+	}
+
+	for i, existing := range records {
+		if existing.ModuleName == record.ModuleName {
+			records[i] = record
+			return saveRegistry(records)
+		}
+	}
+
+	return saveRegistry(append(records, record))
+}
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/vorticist/builder/service"
+	"github.com/vorticist/logger"
+)
+
+var (
+	remoteFlag          string
+	identityFlag        string
+	remotePathFlag      string
+	knownHostsFlag      string
+	insecureHostKeyFlag bool
+)
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&remoteFlag, "remote", "", "Deploy over SSH to user@host[:port] instead of installing locally")
+	rootCmd.PersistentFlags().StringVar(&identityFlag, "identity", "", "SSH private key to authenticate with (default: ssh-agent)")
+	rootCmd.PersistentFlags().StringVar(&remotePathFlag, "remote-path", "", "Remote path for the binary (default: /usr/local/bin/<name>)")
+	rootCmd.PersistentFlags().StringVar(&knownHostsFlag, "known-hosts", "", "Path to a known_hosts file to verify the remote host key against (default: ~/.ssh/known_hosts)")
+	rootCmd.PersistentFlags().BoolVar(&insecureHostKeyFlag, "insecure-host-key", false, "Skip remote host key verification (not recommended; vulnerable to MITM)")
+}
+
+// remoteTarget is a parsed --remote value: user@host[:port].
+type remoteTarget struct {
+	User string
+	Host string
+	Port string
+}
+
+func parseRemoteTarget(remote string) (remoteTarget, error) {
+	userHost, port := remote, "22"
+	if idx := strings.LastIndex(remote, ":"); idx != -1 {
+		userHost, port = remote[:idx], remote[idx+1:]
+	}
+
+	parts := strings.SplitN(userHost, "@", 2)
+	if len(parts) != 2 {
+		return remoteTarget{}, fmt.Errorf("invalid --remote %q, expected user@host[:port]", remote)
+	}
+	return remoteTarget{User: parts[0], Host: parts[1], Port: port}, nil
+}
+
+func remoteBinaryPath(name string) string {
+	if remotePathFlag != "" {
+		return remotePathFlag
+	}
+	return filepath.Join("/usr/local/bin", name)
+}
+
+// deployRemote copies binaryPath and a rendered systemd unit for cfg to
+// target over SSH, then enables and starts the service there.
+func deployRemote(target remoteTarget, cfg service.ServiceConfig, binaryPath string) error {
+	client, err := dialRemote(target)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s@%s: %v", target.User, target.Host, err)
+	}
+	defer client.Close()
+
+	if err := scpFile(client, binaryPath, cfg.ExecStart); err != nil {
+		return fmt.Errorf("failed to copy binary: %v", err)
+	}
+	logger.Infof("Copied %s to %s:%s", binaryPath, target.Host, cfg.ExecStart)
+
+	manifest, err := service.RenderManifest("linux", cfg)
+	if err != nil {
+		return fmt.Errorf("failed to render service manifest: %v", err)
+	}
+
+	tmp, err := os.CreateTemp("", cfg.Name+"-*.service")
+	if err != nil {
+		return fmt.Errorf("failed to create temp manifest: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.WriteString(manifest); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write temp manifest: %v", err)
+	}
+	tmp.Close()
+
+	remoteUnitPath := fmt.Sprintf("/etc/systemd/system/%s.service", cfg.Name)
+	if err := scpFile(client, tmp.Name(), remoteUnitPath); err != nil {
+		return fmt.Errorf("failed to copy service file: %v", err)
+	}
+	logger.Infof("Copied service file to %s:%s", target.Host, remoteUnitPath)
+
+	return runRemote(client, fmt.Sprintf("systemctl daemon-reload && systemctl enable --now %s.service", cfg.Name))
+}
+
+func dialRemote(target remoteTarget) (*ssh.Client, error) {
+	auth, err := remoteAuth()
+	if err != nil {
+		return nil, err
+	}
+
+	hostKeyCallback, err := remoteHostKeyCallback()
+	if err != nil {
+		return nil, err
+	}
+
+	config := &ssh.ClientConfig{
+		User:            target.User,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+	}
+	return ssh.Dial("tcp", net.JoinHostPort(target.Host, target.Port), config)
+}
+
+// remoteHostKeyCallback verifies the remote host key against known_hosts by
+// default, since a blanket InsecureIgnoreHostKey leaves deploys open to a
+// MITM. --insecure-host-key opts back into the old unverified behavior.
+func remoteHostKeyCallback() (ssh.HostKeyCallback, error) {
+	if insecureHostKeyFlag {
+		return ssh.InsecureIgnoreHostKey(), nil
+	}
+
+	path := knownHostsFlag
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve home directory: %v", err)
+		}
+		path = filepath.Join(home, ".ssh/known_hosts")
+	}
+
+	callback, err := knownhosts.New(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load known_hosts at %s (pass --known-hosts or --insecure-host-key): %v", path, err)
+	}
+	return callback, nil
+}
+
+// remoteAuth uses --identity if given, falling back to ssh-agent.
+func remoteAuth() ([]ssh.AuthMethod, error) {
+	if identityFlag != "" {
+		key, err := os.ReadFile(identityFlag)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read --identity: %v", err)
+		}
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse --identity key: %v", err)
+		}
+		return []ssh.AuthMethod{ssh.PublicKeys(signer)}, nil
+	}
+
+	sock := os.Getenv("SSH_AUTH_SOCK")
+	if sock == "" {
+		return nil, fmt.Errorf("no --identity given and SSH_AUTH_SOCK is not set")
+	}
+	conn, err := net.Dial("unix", sock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to ssh-agent: %v", err)
+	}
+	return []ssh.AuthMethod{ssh.PublicKeysCallback(agent.NewClient(conn).Signers)}, nil
+}
+
+// scpFile uploads local to remotePath using the classic `scp -t` protocol
+// over a single SSH session.
+func scpFile(client *ssh.Client, local, remotePath string) error {
+	data, err := os.ReadFile(local)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", local, err)
+	}
+	info, err := os.Stat(local)
+	if err != nil {
+		return err
+	}
+
+	session, err := client.NewSession()
+	if err != nil {
+		return fmt.Errorf("failed to open ssh session: %v", err)
+	}
+	defer session.Close()
+
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		return err
+	}
+
+	if err := session.Start(fmt.Sprintf("scp -qt %s", filepath.Dir(remotePath))); err != nil {
+		return fmt.Errorf("failed to start remote scp: %v", err)
+	}
+
+	fmt.Fprintf(stdin, "C%#o %d %s\n", info.Mode().Perm(), len(data), filepath.Base(remotePath))
+	stdin.Write(data)
+	fmt.Fprint(stdin, "\x00")
+	stdin.Close()
+
+	if err := session.Wait(); err != nil {
+		return fmt.Errorf("remote scp failed: %v", err)
+	}
+	return nil
+}
+
+func runRemote(client *ssh.Client, command string) error {
+	session, err := client.NewSession()
+	if err != nil {
+		return fmt.Errorf("failed to open ssh session: %v", err)
+	}
+	defer session.Close()
+
+	session.Stdout = os.Stdout
+	session.Stderr = os.Stderr
+	if err := session.Run(command); err != nil {
+		return fmt.Errorf("remote command failed: %v", err)
+	}
+	return nil
+}
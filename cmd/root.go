@@ -5,21 +5,45 @@ import (
 	"fmt"
 	"github.com/vorticist/logger"
 	"os"
-	"os/exec"
+	"os/user"
 	"path/filepath"
 	"strings"
 
+	"github.com/vorticist/builder/service"
+
 	"github.com/spf13/cobra"
 )
 
-var installFlag bool
+var (
+	installFlag bool
+	userFlag    bool
+	systemFlag  bool
+
+	descriptionFlag   string
+	userNameFlag      string
+	groupFlag         string
+	workingDirFlag    string
+	restartPolicyFlag string
+	restartSecFlag    int
+	wantedByFlag      string
+	argvFlag          []string
+	envFlag           []string
+	deployPathFlag    string
+
+	osFlag         string
+	archFlag       string
+	cgoFlag        bool
+	tagsFlag       []string
+	ldflagsFlag    []string
+	trimpathFlag   bool
+	versionVarFlag string
+	matrixFlag     string
+)
 
 // rootCmd represents the base command
 var rootCmd = &cobra.Command{
-	Use:   "vbuilder [project-path]",
-	Short: "A CLI to validate, build, and create a service for Go projects",
-	Args:  cobra.ExactArgs(1),
-	Run:   runProjectSetup,
+	Use:   "vbuilder",
+	Short: "A CLI to validate, build, and manage services for Go projects",
 }
 
 func Execute() {
@@ -30,7 +54,127 @@ func Execute() {
 }
 
 func init() {
-	rootCmd.PersistentFlags().BoolVarP(&installFlag, "install", "i", false, "Copy the .service file to systemd folder and enable it")
+	rootCmd.AddCommand(addCmd)
+
+	rootCmd.PersistentFlags().BoolVarP(&installFlag, "install", "i", false, "Install and enable the generated service")
+	rootCmd.PersistentFlags().BoolVar(&userFlag, "user", false, "Install as a per-user service (default unless running as root)")
+	rootCmd.PersistentFlags().BoolVar(&systemFlag, "system", false, "Install as a system-wide service (default when running as root)")
+
+	rootCmd.PersistentFlags().StringVar(&descriptionFlag, "description", "", "Service description (overrides vbuilder.yaml/json)")
+	rootCmd.PersistentFlags().StringVar(&userNameFlag, "user-name", "", "User the service runs as (overrides vbuilder.yaml/json)")
+	rootCmd.PersistentFlags().StringVar(&groupFlag, "group", "", "Group the service runs as (overrides vbuilder.yaml/json)")
+	rootCmd.PersistentFlags().StringVar(&workingDirFlag, "working-dir", "", "Working directory for the service (overrides vbuilder.yaml/json)")
+	rootCmd.PersistentFlags().StringVar(&restartPolicyFlag, "restart-policy", "", "Restart policy, e.g. always, on-failure (overrides vbuilder.yaml/json)")
+	rootCmd.PersistentFlags().IntVar(&restartSecFlag, "restart-sec", 0, "Seconds to wait before restarting (overrides vbuilder.yaml/json)")
+	rootCmd.PersistentFlags().StringVar(&wantedByFlag, "wanted-by", "", "systemd WantedBy target (overrides vbuilder.yaml/json)")
+	rootCmd.PersistentFlags().StringSliceVar(&argvFlag, "argv", nil, "Arguments passed to the service binary (overrides vbuilder.yaml/json)")
+	rootCmd.PersistentFlags().StringSliceVar(&envFlag, "env", nil, "Environment variables as KEY=VALUE (overrides vbuilder.yaml/json)")
+	rootCmd.PersistentFlags().StringVar(&deployPathFlag, "deploy-path", "", "Where `check`/`watch` should additionally copy the binary after a rebuild, e.g. a path served by a reverse proxy")
+
+	rootCmd.PersistentFlags().StringVar(&osFlag, "os", "", "Target GOOS for cross-compilation (default: host GOOS)")
+	rootCmd.PersistentFlags().StringVar(&archFlag, "arch", "", "Target GOARCH for cross-compilation (default: host GOARCH)")
+	rootCmd.PersistentFlags().BoolVar(&cgoFlag, "cgo", false, "Build with CGO_ENABLED=1")
+	rootCmd.PersistentFlags().StringSliceVar(&tagsFlag, "tags", nil, "Go build tags")
+	rootCmd.PersistentFlags().StringSliceVar(&ldflagsFlag, "ldflags", nil, "Extra -ldflags passed to go build")
+	rootCmd.PersistentFlags().BoolVar(&trimpathFlag, "trimpath", false, "Build with -trimpath for reproducible binaries")
+	rootCmd.PersistentFlags().StringVar(&versionVarFlag, "version-var", "", "Set a version variable via -ldflags, e.g. main.Version=v1.2.3")
+	rootCmd.PersistentFlags().StringVar(&matrixFlag, "matrix", "", "Comma-separated GOOS/GOARCH targets, e.g. linux/amd64,linux/arm64,darwin/arm64")
+}
+
+// buildOptionsFromFlags builds a BuildOptions from the CLI's cross-compile flags.
+func buildOptionsFromFlags() BuildOptions {
+	return BuildOptions{
+		GOOS:       osFlag,
+		GOARCH:     archFlag,
+		CGOEnabled: cgoFlag,
+		Tags:       tagsFlag,
+		LDFlags:    ldflagsFlag,
+		Trimpath:   trimpathFlag,
+		VersionVar: versionVarFlag,
+	}
+}
+
+// applyFlagOverrides layers CLI flag values on top of the config loaded
+// from vbuilder.yaml/vbuilder.json, flags taking precedence.
+func applyFlagOverrides(cfg service.ServiceConfig) (service.ServiceConfig, error) {
+	if descriptionFlag != "" {
+		cfg.Description = descriptionFlag
+	}
+	if userNameFlag != "" {
+		cfg.User = userNameFlag
+	}
+	if groupFlag != "" {
+		cfg.Group = groupFlag
+	}
+	if workingDirFlag != "" {
+		cfg.WorkingDir = workingDirFlag
+	}
+	if restartPolicyFlag != "" {
+		cfg.RestartPolicy = restartPolicyFlag
+	}
+	if restartSecFlag != 0 {
+		cfg.RestartSec = restartSecFlag
+	}
+	if wantedByFlag != "" {
+		cfg.WantedBy = wantedByFlag
+	}
+	if len(argvFlag) > 0 {
+		cfg.Argv = argvFlag
+	}
+	if len(envFlag) > 0 {
+		if cfg.Env == nil {
+			cfg.Env = map[string]string{}
+		}
+		for _, kv := range envFlag {
+			key, value, ok := strings.Cut(kv, "=")
+			if !ok {
+				return cfg, fmt.Errorf("invalid --env value %q, expected KEY=VALUE", kv)
+			}
+			cfg.Env[key] = value
+		}
+	}
+	return cfg, nil
+}
+
+// userScope mirrors the serviceman convention: system-wide by default when
+// running as root, per-user otherwise, overridable with --user/--system.
+func userScope() bool {
+	if systemFlag {
+		return false
+	}
+	if userFlag {
+		return true
+	}
+	return !isRoot()
+}
+
+func isRoot() bool {
+	current, err := user.Current()
+	if err != nil {
+		return false
+	}
+	return current.Uid == "0"
+}
+
+// resolveServiceScope determines which scope to target a named service's
+// lifecycle command at. An explicit --user/--system flag always wins;
+// otherwise it looks up the scope the service was actually installed with,
+// since the invoking user's own default (userScope) has no bearing on how
+// a *different* service was installed.
+func resolveServiceScope(cmd *cobra.Command, name string) (bool, error) {
+	if cmd.Flags().Changed("user") || cmd.Flags().Changed("system") {
+		return userScope(), nil
+	}
+
+	record, ok, err := findByService(name)
+	if err != nil {
+		return false, err
+	}
+	if ok {
+		return record.UserScope, nil
+	}
+
+	return false, fmt.Errorf("%s is not a registered service; pass --user or --system to specify its scope", name)
 }
 
 func runProjectSetup(cmd *cobra.Command, args []string) {
@@ -43,23 +187,119 @@ func runProjectSetup(cmd *cobra.Command, args []string) {
 		return
 	}
 
-	// Step 2: Build the project
-	binaryName := moduleName
-	binaryPath, err := buildGoProject(projectPath, binaryName)
+	cfg, err := loadProjectServiceConfig(projectPath)
+	if err != nil {
+		logger.Errorf("Failed to load vbuilder.yaml/vbuilder.json: %v", err)
+		return
+	}
+	cfg, err = applyFlagOverrides(cfg)
 	if err != nil {
-		logger.Errorf("Failed to build the Go project: %v", err)
+		logger.Errorf("Invalid flag: %v", err)
 		return
 	}
 
-	// Step 3: Generate the .service file using the absolute path of the binary
-	serviceFilePath := filepath.Join(projectPath, fmt.Sprintf("%s.service", binaryName))
-	generateServiceFile(serviceFilePath, binaryPath, binaryName)
+	// Step 2: Build the project, either a single native binary or, with
+	// --matrix, one binary per target platform
+	binaryName := moduleName
+	opts := buildOptionsFromFlags()
+
+	cfg.Name = binaryName
+	if cfg.Description == "" {
+		cfg.Description = fmt.Sprintf("vortex.studio/%s Service", binaryName)
+	}
+
+	var binaryPath string
+	if matrixFlag != "" {
+		results, err := buildMatrix(projectPath, binaryName, matrixFlag, opts, cfg)
+		if err != nil {
+			logger.Errorf("Matrix build failed: %v", err)
+			return
+		}
+		binaryPath = selectInstallBinary(results, remoteFlag != "")
+		if binaryPath == "" {
+			logger.Infof("No matrix target can be installed from here; skipping service install.")
+			return
+		}
+	} else {
+		binaryPath, err = buildGoProject(projectPath, binaryName, opts)
+		if err != nil {
+			logger.Errorf("Failed to build the Go project: %v", err)
+			return
+		}
+	}
+
+	record := ProjectRecord{
+		RootPath:   projectPath,
+		BinaryPath: binaryPath,
+		ModuleName: binaryName,
+		DeployPath: deployPathFlag,
+	}
+
+	switch {
+	case remoteFlag != "":
+		// Step 3: Deploy to a remote host over SSH instead of installing locally
+		target, err := parseRemoteTarget(remoteFlag)
+		if err != nil {
+			logger.Errorf("%v", err)
+			return
+		}
+
+		cfg.ExecStart = remoteBinaryPath(binaryName)
+		cfg.UserScope = false
+		if cfg.WorkingDir == "" {
+			cfg.WorkingDir = filepath.Dir(cfg.ExecStart)
+		}
+
+		if err := deployRemote(target, cfg, binaryPath); err != nil {
+			logger.Errorf("Remote deploy failed: %v", err)
+			return
+		}
+
+		logger.Infof("Service %s deployed and started on %s.", binaryName, target.Host)
+		// record.InstalledService is deliberately left unset: it marks a
+		// service as manageable by check/start/stop/restart/status/logs,
+		// all of which operate on this machine. A --remote deploy's unit
+		// lives on target.Host, not here, so registering it would make
+		// those commands either error against a nonexistent local unit or
+		// silently operate on an unrelated local service of the same name.
+
+	case installFlag:
+		// Step 3: Install the service locally using the platform-native backend
+		cfg.ExecStart = binaryPath
+		cfg.UserScope = userScope()
+		if cfg.User == "" {
+			cfg.User = os.Getenv("USER")
+		}
+		if cfg.WorkingDir == "" {
+			cfg.WorkingDir = filepath.Dir(binaryPath)
+		}
+
+		manager, err := service.New(cfg)
+		if err != nil {
+			logger.Errorf("Failed to select service manager: %v", err)
+			return
+		}
 
-	logger.Infof("Service file created at: %v", serviceFilePath)
+		if err := manager.Install(); err != nil {
+			logger.Errorf("Failed to install service: %v", err)
+			return
+		}
 
-	// Step 4: Optionally install the service file
-	if installFlag {
-		copyServiceToSystemd(serviceFilePath, binaryName)
+		if err := manager.Start(); err != nil {
+			logger.Errorf("Failed to start service: %v", err)
+			return
+		}
+
+		logger.Infof("Service %s installed and started.", binaryName)
+
+		record.InstalledService = binaryName
+		record.UserScope = cfg.UserScope
+		record.Owner = cfg.User
+		record.Group = cfg.Group
+	}
+
+	if err := upsertRegistry(record); err != nil {
+		logger.Errorf("Failed to update project registry: %v", err)
 	}
 }
 
@@ -90,89 +330,3 @@ func getModuleName(projectPath string) (string, error) {
 	return "", fmt.Errorf("module name not found in go.mod")
 }
 
-func buildGoProject(projectPath, binaryName string) (string, error) {
-	// Step 1: Build the binary and output it directly to the root of the project
-	binaryPath := filepath.Join(projectPath, binaryName)
-	cmd := exec.Command("go", "build", "-o", binaryPath, projectPath)
-	cmd.Dir = projectPath
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		return "", err
-	}
-
-	// Return the absolute path of the binary
-	absBinaryPath, err := filepath.Abs(binaryPath)
-	if err != nil {
-		return "", fmt.Errorf("failed to get absolute path of binary: %v", err)
-	}
-
-	return absBinaryPath, nil
-}
-
-func generateServiceFile(servicePath, binaryPath, serviceName string) {
-	serviceContent := fmt.Sprintf(`[Unit]
-Description=vortex.studio/%s Service
-After=network.target
-
-[Service]
-ExecStart=%s
-Restart=always
-User=%s
-WorkingDirectory=%s
-RestartSec=10
-
-[Install]
-WantedBy=multi-user.target
-`, serviceName, binaryPath, os.Getenv("USER"), filepath.Dir(binaryPath))
-
-	// Write to .service file
-	os.WriteFile(servicePath, []byte(serviceContent), 0644)
-}
-
-func copyServiceToSystemd(serviceFilePath, serviceName string) {
-	systemdPath := fmt.Sprintf("/etc/systemd/system/%s.service", serviceName)
-
-	// Use sudo to copy the service file
-	copyCmd := exec.Command("sudo", "cp", serviceFilePath, systemdPath)
-	copyCmd.Stdout = os.Stdout
-	copyCmd.Stderr = os.Stderr
-	err := copyCmd.Run()
-	if err != nil {
-		logger.Errorf("Failed to copy service file to systemd: %v", err)
-		return
-	}
-	logger.Infof("Service file copied to: %v", systemdPath)
-
-	// Reload systemd daemon
-	reloadCmd := exec.Command("sudo", "systemctl", "daemon-reload")
-	reloadCmd.Stdout = os.Stdout
-	reloadCmd.Stderr = os.Stderr
-	err = reloadCmd.Run()
-	if err != nil {
-		logger.Errorf("Failed to reload systemd daemon: %v", err)
-		return
-	}
-
-	// Enable the service
-	enableCmd := exec.Command("sudo", "systemctl", "enable", fmt.Sprintf("%s.service", serviceName))
-	enableCmd.Stdout = os.Stdout
-	enableCmd.Stderr = os.Stderr
-	err = enableCmd.Run()
-	if err != nil {
-		logger.Errorf("Failed to enable service: %v", err)
-		return
-	}
-
-	// Start the service
-	startCmd := exec.Command("sudo", "systemctl", "start", fmt.Sprintf("%s.service", serviceName))
-	startCmd.Stdout = os.Stdout
-	startCmd.Stderr = os.Stderr
-	err = startCmd.Run()
-	if err != nil {
-		logger.Errorf("Failed to start service: %v", err)
-		return
-	}
-
-	logger.Info("Service enabled and started successfully.")
-}
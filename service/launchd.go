@@ -0,0 +1,143 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/vorticist/logger"
+)
+
+// launchdManager manages a service via launchd, either as a per-user
+// LaunchAgent under ~/Library/LaunchAgents or a system-wide LaunchDaemon
+// under /Library/LaunchDaemons (requires root/sudo).
+type launchdManager struct {
+	cfg ServiceConfig
+}
+
+func newLaunchdManager(cfg ServiceConfig) *launchdManager {
+	return &launchdManager{cfg: cfg}
+}
+
+func (m *launchdManager) label() string {
+	return fmt.Sprintf("studio.vortex.%s", m.cfg.Name)
+}
+
+func (m *launchdManager) plistPath() (string, error) {
+	if !m.cfg.UserScope {
+		return filepath.Join("/Library/LaunchDaemons", m.label()+".plist"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %v", err)
+	}
+	return filepath.Join(home, "Library/LaunchAgents", m.label()+".plist"), nil
+}
+
+func (m *launchdManager) Install() error {
+	plistPath, err := m.plistPath()
+	if err != nil {
+		return err
+	}
+
+	content, err := renderTemplate("launchd.plist", launchdTemplate, launchdTemplateData{
+		ServiceConfig: m.cfg,
+		Label:         m.label(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if m.cfg.UserScope {
+		if err := os.MkdirAll(filepath.Dir(plistPath), 0755); err != nil {
+			return fmt.Errorf("failed to create LaunchAgents directory: %v", err)
+		}
+		if err := os.WriteFile(plistPath, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write plist: %v", err)
+		}
+	} else {
+		tmp, err := os.CreateTemp("", m.cfg.Name+"-*.plist")
+		if err != nil {
+			return fmt.Errorf("failed to create temp plist: %v", err)
+		}
+		defer os.Remove(tmp.Name())
+		if _, err := tmp.WriteString(content); err != nil {
+			tmp.Close()
+			return fmt.Errorf("failed to write temp plist: %v", err)
+		}
+		tmp.Close()
+
+		copyCmd := exec.Command("sudo", "cp", tmp.Name(), plistPath)
+		copyCmd.Stdout = os.Stdout
+		copyCmd.Stderr = os.Stderr
+		if err := copyCmd.Run(); err != nil {
+			return fmt.Errorf("failed to copy plist to %s: %v", plistPath, err)
+		}
+	}
+
+	logger.Infof("Plist installed at: %v", plistPath)
+	return m.launchctl("load", "-w", plistPath)
+}
+
+func (m *launchdManager) Uninstall() error {
+	plistPath, err := m.plistPath()
+	if err != nil {
+		return err
+	}
+
+	_ = m.launchctl("unload", "-w", plistPath)
+
+	if m.cfg.UserScope {
+		return os.Remove(plistPath)
+	}
+
+	rmCmd := exec.Command("sudo", "rm", "-f", plistPath)
+	rmCmd.Stdout = os.Stdout
+	rmCmd.Stderr = os.Stderr
+	return rmCmd.Run()
+}
+
+func (m *launchdManager) Start() error {
+	return m.launchctl("start", m.label())
+}
+
+func (m *launchdManager) Stop() error {
+	return m.launchctl("stop", m.label())
+}
+
+func (m *launchdManager) Status() (Status, error) {
+	out, err := exec.Command("launchctl", "list", m.label()).CombinedOutput()
+	return resolveStatus(string(out), parseLaunchdPID(string(out))), err
+}
+
+var launchdPIDPattern = regexp.MustCompile(`"PID"\s*=\s*(\d+);`)
+
+// parseLaunchdPID reads the PID entry out of `launchctl list <label>`, which
+// for a single label prints a property-list dict (`"PID" = 1234;` among
+// other keys), not the "PID\tStatus\tLabel" table bare `launchctl list`
+// prints when listing every job.
+func parseLaunchdPID(out string) int {
+	match := launchdPIDPattern.FindStringSubmatch(out)
+	if match == nil {
+		return 0
+	}
+	pid, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0
+	}
+	return pid
+}
+
+func (m *launchdManager) launchctl(args ...string) error {
+	cmd := exec.Command("launchctl", args...)
+	if !m.cfg.UserScope {
+		cmd = exec.Command("sudo", append([]string{"launchctl"}, args...)...)
+	}
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,104 @@
+// Package service provides platform-specific backends for installing and
+// controlling vbuilder-generated services (systemd on Linux, launchd on
+// macOS, and the Windows service manager).
+package service
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// ServiceConfig describes the service to be managed and templated. It can be
+// built from CLI flags alone or loaded from a vbuilder.yaml/vbuilder.json in
+// the project root, with flags taking precedence over file values.
+type ServiceConfig struct {
+	// Name is the short service name, e.g. the built binary's name.
+	Name string `yaml:"name" json:"name"`
+	// Description is a human-readable summary shown by the OS service manager.
+	Description string `yaml:"description" json:"description"`
+	// ExecStart is the absolute path to the binary to run.
+	ExecStart string `yaml:"exec" json:"exec"`
+	// Argv are the arguments passed to ExecStart.
+	Argv []string `yaml:"argv" json:"argv"`
+	// Env are environment variables set for the running service.
+	Env map[string]string `yaml:"env" json:"env"`
+	// User is the account the service should run as.
+	User string `yaml:"user" json:"user"`
+	// Group is the group the service should run as.
+	Group string `yaml:"group" json:"group"`
+	// WorkingDir is the directory the service is run from.
+	WorkingDir string `yaml:"working_dir" json:"working_dir"`
+	// After lists units/services that must start before this one.
+	After []string `yaml:"after" json:"after"`
+	// Requires lists units/services this one depends on.
+	Requires []string `yaml:"requires" json:"requires"`
+	// RestartPolicy mirrors systemd's Restart= values (e.g. "always", "on-failure").
+	RestartPolicy string `yaml:"restart_policy" json:"restart_policy"`
+	// RestartSec is the delay, in seconds, before a restart is attempted.
+	RestartSec int `yaml:"restart_sec" json:"restart_sec"`
+	// WantedBy mirrors systemd's WantedBy= target (e.g. "multi-user.target").
+	WantedBy string `yaml:"wanted_by" json:"wanted_by"`
+	// UserScope selects a per-user service (systemd --user, a LaunchAgent,
+	// or a per-user Windows run key) instead of a system-wide one.
+	UserScope bool `yaml:"-" json:"-"`
+}
+
+// withDefaults fills in the same defaults the original hard-coded unit used,
+// for any field the caller and project config left unset.
+func (c ServiceConfig) withDefaults() ServiceConfig {
+	if c.RestartPolicy == "" {
+		c.RestartPolicy = "always"
+	}
+	if c.RestartSec == 0 {
+		c.RestartSec = 10
+	}
+	if len(c.After) == 0 {
+		c.After = []string{"network.target"}
+	}
+	if c.WantedBy == "" {
+		if c.UserScope {
+			c.WantedBy = "default.target"
+		} else {
+			c.WantedBy = "multi-user.target"
+		}
+	}
+	return c
+}
+
+// Manager installs and controls a single service using a platform-native
+// backend.
+type Manager interface {
+	Install() error
+	Uninstall() error
+	Start() error
+	Stop() error
+	Status() (Status, error)
+}
+
+// Status describes the observed state of a service.
+type Status struct {
+	// Raw is the unparsed output from the platform's native status query.
+	Raw string
+	// PID is the process id the platform reports for the service, or 0 if unknown.
+	PID int
+	// Running is true only when PID is non-zero and a live process with that
+	// PID was actually found, independent of what the service manager claims.
+	Running bool
+}
+
+// New returns the Manager appropriate for the current GOOS. Note that
+// cfg.Env is unsupported on windows: the Service Control Manager has no
+// per-service environment block, so Env is silently ignored there.
+func New(cfg ServiceConfig) (Manager, error) {
+	cfg = cfg.withDefaults()
+	switch runtime.GOOS {
+	case "linux":
+		return newSystemdManager(cfg), nil
+	case "darwin":
+		return newLaunchdManager(cfg), nil
+	case "windows":
+		return newWindowsManager(cfg), nil
+	default:
+		return nil, fmt.Errorf("service: unsupported platform %q", runtime.GOOS)
+	}
+}
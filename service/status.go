@@ -0,0 +1,19 @@
+package service
+
+import (
+	gops "github.com/mitchellh/go-ps"
+)
+
+// resolveStatus cross-checks a reported PID against the live process table,
+// since a service manager reporting "active" doesn't guarantee the process
+// behind it is actually still running.
+func resolveStatus(raw string, pid int) Status {
+	status := Status{Raw: raw, PID: pid}
+	if pid <= 0 {
+		return status
+	}
+
+	proc, err := gops.FindProcess(pid)
+	status.Running = err == nil && proc != nil
+	return status
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/vorticist/logger"
+)
+
+var systemdPIDPattern = regexp.MustCompile(`Main PID:\s*(\d+)`)
+
+// systemdManager manages a service via systemd, either as a system unit
+// under /etc/systemd/system (requires root/sudo) or a user unit under
+// ~/.config/systemd/user (no sudo required).
+type systemdManager struct {
+	cfg ServiceConfig
+}
+
+func newSystemdManager(cfg ServiceConfig) *systemdManager {
+	return &systemdManager{cfg: cfg}
+}
+
+func (m *systemdManager) unitPath() (string, error) {
+	if !m.cfg.UserScope {
+		return filepath.Join("/etc/systemd/system", m.cfg.Name+".service"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %v", err)
+	}
+	return filepath.Join(home, ".config/systemd/user", m.cfg.Name+".service"), nil
+}
+
+func (m *systemdManager) systemctl(args ...string) error {
+	var cmd *exec.Cmd
+	if m.cfg.UserScope {
+		cmd = exec.Command("systemctl", append([]string{"--user"}, args...)...)
+	} else {
+		cmd = exec.Command("sudo", append([]string{"systemctl"}, args...)...)
+	}
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+func (m *systemdManager) Install() error {
+	unitPath, err := m.unitPath()
+	if err != nil {
+		return err
+	}
+
+	content, err := renderTemplate("systemd.service", systemdTemplate, m.cfg)
+	if err != nil {
+		return err
+	}
+
+	if m.cfg.UserScope {
+		if err := os.MkdirAll(filepath.Dir(unitPath), 0755); err != nil {
+			return fmt.Errorf("failed to create systemd user directory: %v", err)
+		}
+		if err := os.WriteFile(unitPath, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write unit file: %v", err)
+		}
+	} else {
+		tmp, err := os.CreateTemp("", m.cfg.Name+"-*.service")
+		if err != nil {
+			return fmt.Errorf("failed to create temp unit file: %v", err)
+		}
+		defer os.Remove(tmp.Name())
+		if _, err := tmp.WriteString(content); err != nil {
+			tmp.Close()
+			return fmt.Errorf("failed to write temp unit file: %v", err)
+		}
+		tmp.Close()
+
+		copyCmd := exec.Command("sudo", "cp", tmp.Name(), unitPath)
+		copyCmd.Stdout = os.Stdout
+		copyCmd.Stderr = os.Stderr
+		if err := copyCmd.Run(); err != nil {
+			return fmt.Errorf("failed to copy unit file to %s: %v", unitPath, err)
+		}
+	}
+
+	if err := m.systemctl("daemon-reload"); err != nil {
+		return fmt.Errorf("failed to reload systemd daemon: %v", err)
+	}
+
+	logger.Infof("Unit file installed at: %v", unitPath)
+	return m.systemctl("enable", m.cfg.Name+".service")
+}
+
+func (m *systemdManager) Uninstall() error {
+	unitPath, err := m.unitPath()
+	if err != nil {
+		return err
+	}
+
+	_ = m.systemctl("disable", "--now", m.cfg.Name+".service")
+
+	if m.cfg.UserScope {
+		return os.Remove(unitPath)
+	}
+
+	rmCmd := exec.Command("sudo", "rm", "-f", unitPath)
+	rmCmd.Stdout = os.Stdout
+	rmCmd.Stderr = os.Stderr
+	return rmCmd.Run()
+}
+
+func (m *systemdManager) Start() error {
+	return m.systemctl("start", m.cfg.Name+".service")
+}
+
+func (m *systemdManager) Stop() error {
+	return m.systemctl("stop", m.cfg.Name+".service")
+}
+
+func (m *systemdManager) Status() (Status, error) {
+	args := []string{"status", m.cfg.Name + ".service"}
+	if m.cfg.UserScope {
+		args = append([]string{"--user"}, args...)
+	}
+
+	out, err := exec.Command("systemctl", args...).CombinedOutput()
+	return resolveStatus(string(out), parseSystemdPID(string(out))), err
+}
+
+func parseSystemdPID(out string) int {
+	match := systemdPIDPattern.FindStringSubmatch(out)
+	if match == nil {
+		return 0
+	}
+	pid, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0
+	}
+	return pid
+}
+
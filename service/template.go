@@ -0,0 +1,66 @@
+package service
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/xml"
+	"fmt"
+	"text/template"
+)
+
+//go:embed templates/systemd.service.tmpl
+var systemdTemplate string
+
+//go:embed templates/launchd.plist.tmpl
+var launchdTemplate string
+
+// launchdTemplateData adds the launchd-specific Label on top of ServiceConfig.
+type launchdTemplateData struct {
+	ServiceConfig
+	Label string
+}
+
+// RenderManifest renders the service manifest for goos (a systemd unit for
+// linux, a launchd plist for darwin) without installing it anywhere. Used
+// to produce a manifest for a cross-compiled binary that will be deployed
+// to a machine other than the one vbuilder is running on.
+func RenderManifest(goos string, cfg ServiceConfig) (string, error) {
+	cfg = cfg.withDefaults()
+	switch goos {
+	case "linux":
+		return renderTemplate("systemd.service", systemdTemplate, cfg)
+	case "darwin":
+		return renderTemplate("launchd.plist", launchdTemplate, launchdTemplateData{
+			ServiceConfig: cfg,
+			Label:         fmt.Sprintf("studio.vortex.%s", cfg.Name),
+		})
+	default:
+		return "", fmt.Errorf("service: no manifest template for %q", goos)
+	}
+}
+
+var templateFuncs = template.FuncMap{
+	"xmlEscape": xmlEscape,
+}
+
+func renderTemplate(name, tmpl string, data any) (string, error) {
+	t, err := template.New(name).Funcs(templateFuncs).Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s template: %v", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %v", name, err)
+	}
+	return buf.String(), nil
+}
+
+// xmlEscape escapes text for safe use inside XML element content, e.g. the
+// launchd plist template, where a raw `&`, `<`, or `>` in an Env or Argv
+// value would otherwise produce an invalid plist.
+func xmlEscape(s string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
@@ -0,0 +1,135 @@
+//go:build windows
+
+package service
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/mgr"
+)
+
+// windowsManager manages a service via the Windows Service Control Manager.
+// For UserScope services (no SCM access without elevation) it falls back
+// to a per-user autostart entry under
+// HKEY_CURRENT_USER\Software\Microsoft\Windows\CurrentVersion\Run.
+type windowsManager struct {
+	cfg ServiceConfig
+}
+
+func newWindowsManager(cfg ServiceConfig) *windowsManager {
+	return &windowsManager{cfg: cfg}
+}
+
+func (m *windowsManager) Install() error {
+	if m.cfg.UserScope {
+		return installRunKey(m.cfg.Name, m.cfg.ExecStart, m.cfg.Argv)
+	}
+
+	manager, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to service manager: %v", err)
+	}
+	defer manager.Disconnect()
+
+	// Env is not passed to CreateService: the Windows SCM has no per-service
+	// environment block equivalent to systemd's Environment= or launchd's
+	// EnvironmentVariables, so m.cfg.Env is silently unsupported here.
+	service, err := manager.CreateService(m.cfg.Name, m.cfg.ExecStart, mgr.Config{
+		DisplayName: m.cfg.Name,
+		Description: m.cfg.Description,
+		StartType:   mgr.StartAutomatic,
+	}, m.cfg.Argv...)
+	if err != nil {
+		return fmt.Errorf("failed to create service: %v", err)
+	}
+	defer service.Close()
+
+	return nil
+}
+
+func (m *windowsManager) Uninstall() error {
+	if m.cfg.UserScope {
+		return removeRunKey(m.cfg.Name)
+	}
+
+	manager, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to service manager: %v", err)
+	}
+	defer manager.Disconnect()
+
+	service, err := manager.OpenService(m.cfg.Name)
+	if err != nil {
+		return fmt.Errorf("failed to open service: %v", err)
+	}
+	defer service.Close()
+
+	_, _ = service.Control(svc.Stop)
+	return service.Delete()
+}
+
+func (m *windowsManager) Start() error {
+	if m.cfg.UserScope {
+		return fmt.Errorf("service: user-scope services start at login; nothing to do")
+	}
+
+	manager, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to service manager: %v", err)
+	}
+	defer manager.Disconnect()
+
+	service, err := manager.OpenService(m.cfg.Name)
+	if err != nil {
+		return fmt.Errorf("failed to open service: %v", err)
+	}
+	defer service.Close()
+
+	return service.Start()
+}
+
+func (m *windowsManager) Stop() error {
+	if m.cfg.UserScope {
+		return fmt.Errorf("service: user-scope services have no controllable lifecycle")
+	}
+
+	manager, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to service manager: %v", err)
+	}
+	defer manager.Disconnect()
+
+	service, err := manager.OpenService(m.cfg.Name)
+	if err != nil {
+		return fmt.Errorf("failed to open service: %v", err)
+	}
+	defer service.Close()
+
+	_, err = service.Control(svc.Stop)
+	return err
+}
+
+func (m *windowsManager) Status() (Status, error) {
+	if m.cfg.UserScope {
+		return Status{}, fmt.Errorf("service: user-scope services have no controllable lifecycle")
+	}
+
+	manager, err := mgr.Connect()
+	if err != nil {
+		return Status{}, fmt.Errorf("failed to connect to service manager: %v", err)
+	}
+	defer manager.Disconnect()
+
+	service, err := manager.OpenService(m.cfg.Name)
+	if err != nil {
+		return Status{}, fmt.Errorf("failed to open service: %v", err)
+	}
+	defer service.Close()
+
+	status, err := service.Query()
+	if err != nil {
+		return Status{}, err
+	}
+	return resolveStatus(fmt.Sprintf("state=%d", status.State), int(status.ProcessId)), nil
+}
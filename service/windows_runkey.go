@@ -0,0 +1,50 @@
+//go:build windows
+
+package service
+
+import (
+	"strings"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+const runKeyPath = `Software\Microsoft\Windows\CurrentVersion\Run`
+
+func installRunKey(name, execStart string, argv []string) error {
+	key, _, err := registry.CreateKey(registry.CURRENT_USER, runKeyPath, registry.SET_VALUE)
+	if err != nil {
+		return err
+	}
+	defer key.Close()
+
+	return key.SetStringValue(name, commandLine(execStart, argv))
+}
+
+// commandLine builds the command line stored in the Run value, quoting
+// execStart and each argv entry that contains whitespace so the shell that
+// expands the Run value at login doesn't split them apart.
+func commandLine(execStart string, argv []string) string {
+	parts := make([]string, 0, len(argv)+1)
+	parts = append(parts, quoteArg(execStart))
+	for _, arg := range argv {
+		parts = append(parts, quoteArg(arg))
+	}
+	return strings.Join(parts, " ")
+}
+
+func quoteArg(arg string) string {
+	if arg != "" && !strings.ContainsAny(arg, " \t\"") {
+		return arg
+	}
+	return `"` + strings.ReplaceAll(arg, `"`, `\"`) + `"`
+}
+
+func removeRunKey(name string) error {
+	key, err := registry.OpenKey(registry.CURRENT_USER, runKeyPath, registry.SET_VALUE)
+	if err != nil {
+		return err
+	}
+	defer key.Close()
+
+	return key.DeleteValue(name)
+}
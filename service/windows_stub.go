@@ -0,0 +1,26 @@
+//go:build !windows
+
+package service
+
+import "fmt"
+
+// windowsManager is a stand-in compiled into every non-Windows build of
+// vbuilder, where New never selects it; the real implementation in
+// windows.go is only compiled into Windows builds.
+type windowsManager struct {
+	cfg ServiceConfig
+}
+
+func newWindowsManager(cfg ServiceConfig) *windowsManager {
+	return &windowsManager{cfg: cfg}
+}
+
+func (m *windowsManager) unsupported() error {
+	return fmt.Errorf("service: windows service management is not available on this build")
+}
+
+func (m *windowsManager) Install() error         { return m.unsupported() }
+func (m *windowsManager) Uninstall() error       { return m.unsupported() }
+func (m *windowsManager) Start() error           { return m.unsupported() }
+func (m *windowsManager) Stop() error            { return m.unsupported() }
+func (m *windowsManager) Status() (Status, error) { return Status{}, m.unsupported() }